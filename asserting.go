@@ -5,15 +5,19 @@ package asserting
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 	"unicode"
 	"unicode/utf8"
 )
@@ -24,15 +28,31 @@ type TestCase struct {
 	T            *testing.T
 	err          error
 	server       *httptest.Server
+	handler      http.Handler
 	response     *http.Response
+	request      *http.Request
 }
 
-// NewWebTestCase returns an initialized TestCase for Web API testing.
+// NewWebTestCase returns an initialized TestCase for Web API testing. It
+// starts a real httptest.Server and registers t.Cleanup(tc.Close), so the
+// server is shut down automatically even if the test doesn't close it
+// itself.
 func NewWebTestCase(t *testing.T, handlers http.Handler) *TestCase {
-	return &TestCase{
+	tc := &TestCase{
 		T:      t,
 		server: httptest.NewServer(handlers),
 	}
+	t.Cleanup(tc.Close)
+	return tc
+}
+
+// NewMockTestCase returns a TestCase that dispatches Get, Post, Put,
+// Delete, Patch, Head, and Request calls directly against handler through
+// an httptest.ResponseRecorder, without starting a real TCP listener. This
+// is considerably faster than NewWebTestCase for large suites that don't
+// need a live server.
+func NewMockTestCase(t *testing.T, handler http.Handler) *TestCase {
+	return &TestCase{T: t, handler: handler}
 }
 
 // NewTestCase returns an initialized TestCase.
@@ -40,34 +60,129 @@ func NewTestCase(t *testing.T) *TestCase {
 	return &TestCase{T: t}
 }
 
+// Close shuts down the test server, waiting up to 5 seconds for
+// outstanding requests to finish before giving up. It's a no-op for a
+// TestCase built with NewMockTestCase or NewTestCase, and safe to call more
+// than once. NewWebTestCase registers this as a t.Cleanup, so tests don't
+// need to call it themselves.
+func (t *TestCase) Close() {
+	if t.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := t.server.Config.Shutdown(ctx); err != nil {
+		t.T.Errorf("Failed to shut down test server: %s [%s]", err, CallerInfo())
+	}
+}
+
 // Run expects a type that extends TestCase and calls all methods with prefix
-// "Test".
+// "Test", reporting each one as a Go subtest. It also supports a handful of
+// xUnit/BDD-style lifecycle and filtering conventions:
+//
+//   - BeforeAll / AfterAll run once, before and after the whole suite.
+//   - BeforeEach / AfterEach run around every test, AfterEach even if the
+//     test panics.
+//   - FTest-prefixed methods focus the suite: when any exist, only those run.
+//   - XTest-prefixed methods are skipped.
 func Run(i interface{}) {
 	value := reflect.ValueOf(i)
 	testType := reflect.TypeOf(i)
-	var be bool
-	var ba bool
+
+	var be, ba, ae, aa bool
+	var tests, focused, skipped []reflect.Method
 	for i := 0; i < testType.NumMethod(); i++ {
 		method := testType.Method(i)
-		if strings.HasPrefix(method.Name, "BeforeEach") {
+		switch {
+		case strings.HasPrefix(method.Name, "BeforeEach"):
 			be = true
-		} else if strings.HasPrefix(method.Name, "BeforeAll") {
+		case strings.HasPrefix(method.Name, "BeforeAll"):
 			ba = true
+		case strings.HasPrefix(method.Name, "AfterEach"):
+			ae = true
+		case strings.HasPrefix(method.Name, "AfterAll"):
+			aa = true
+		case strings.HasPrefix(method.Name, "FTest"):
+			focused = append(focused, method)
+		case strings.HasPrefix(method.Name, "XTest"):
+			skipped = append(skipped, method)
+		case strings.HasPrefix(method.Name, "Test"):
+			tests = append(tests, method)
 		}
 	}
+	if len(focused) > 0 {
+		tests = focused
+	}
+
+	fields := value
+	if fields.Kind() == reflect.Ptr {
+		fields = fields.Elem()
+	}
+	t, ok := fields.FieldByName("T").Interface().(*testing.T)
+	if !ok || t == nil {
+		panic("asserting: Run requires a T *testing.T field")
+	}
+
 	if ba {
 		value.MethodByName("BeforeAll").Call(nil)
 	}
+	if aa {
+		defer runAfter(value, "AfterAll")
+	}
+
 	fmt.Printf("===> Running tests...\n")
-	for i := 0; i < testType.NumMethod(); i++ {
-		method := testType.Method(i)
-		if strings.HasPrefix(method.Name, "Test") {
+	for _, method := range skipped {
+		t.Run(method.Name, func(st *testing.T) {
+			st.Skip("skipped: " + method.Name)
+		})
+	}
+	for _, method := range tests {
+		method := method
+		t.Run(method.Name, func(st *testing.T) {
+			suite := subject(value, st)
 			if be {
-				value.MethodByName("BeforeEach").Call(nil)
+				suite.MethodByName("BeforeEach").Call(nil)
 			}
-			finalMethod := value.MethodByName(method.Name)
-			finalMethod.Call(nil)
-		}
+			if ae {
+				defer runAfter(suite, "AfterEach")
+			}
+			suite.MethodByName(method.Name).Call(nil)
+		})
+	}
+}
+
+// subject returns a copy of the suite described by value with its T field
+// pointed at st, addressable so lifecycle and test methods observe the
+// subtest's *testing.T rather than the parent's.
+func subject(value reflect.Value, st *testing.T) reflect.Value {
+	isPtr := value.Kind() == reflect.Ptr
+	elemType := value.Type()
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	ptr := reflect.New(elemType)
+	if isPtr {
+		ptr.Elem().Set(value.Elem())
+	} else {
+		ptr.Elem().Set(value)
+	}
+	ptr.Elem().FieldByName("T").Set(reflect.ValueOf(st))
+	if isPtr {
+		return ptr
+	}
+	return ptr.Elem()
+}
+
+// runAfter recovers from a panic in its own goroutine so that the named
+// teardown method still runs, then re-panics so the failure keeps surfacing
+// to `go test`. Deferred per subtest, it lets AfterEach run after a
+// panicking test; a test's panic happens in the subtest's goroutine, so it
+// is not recovered by the AfterAll deferred in Run.
+func runAfter(value reflect.Value, name string) {
+	r := recover()
+	value.MethodByName(name).Call(nil)
+	if r != nil {
+		panic(r)
 	}
 }
 
@@ -138,26 +253,70 @@ func (t TestCase) AssertStatus(code int) {
 
 // Get issues an HTTP GET request and keeps the response for later assertions.
 func (t *TestCase) Get(url string) {
-	if t.server == nil {
+	t.do("GET", url, "", nil)
+}
+
+// Post issues an HTTP POST request and keeps the response for later assertions.
+func (t *TestCase) Post(url string, contentType string, body []byte) {
+	t.do("POST", url, contentType, body)
+}
+
+// Put issues an HTTP PUT request and keeps the response for later assertions
+func (t *TestCase) Put(url string, contentType string, body []byte) {
+	t.do("PUT", url, contentType, body)
+}
+
+// Delete issues an HTTP DELETE request and keeps the response for later
+// assertions.
+func (t *TestCase) Delete(url string) {
+	t.do("DELETE", url, "", nil)
+}
+
+// Patch issues an HTTP PATCH request and keeps the response for later
+// assertions.
+func (t *TestCase) Patch(url string, contentType string, body []byte) {
+	t.do("PATCH", url, contentType, body)
+}
+
+// Head issues an HTTP HEAD request and keeps the response for later
+// assertions. HEAD responses have no body, so ResponseBody is left empty.
+func (t *TestCase) Head(url string) {
+	if t.server == nil && t.handler == nil {
 		t.T.Fatalf("Uninitialized test server [%s]", CallerInfo())
 	}
-	url = t.server.URL + url
-	resp, err := http.Get(url)
+	req, err := http.NewRequest("HEAD", t.targetURL(url), nil)
+	if err != nil {
+		t.T.Fatalf("Failed to create new request: %s [%s]", err, CallerInfo())
+	}
+	resp, err := t.dispatch(req)
+	t.request = req
 	t.response = resp
 	t.err = err
-	if err == nil {
-		defer t.response.Body.Close()
-		t.ResponseBody, t.err = ioutil.ReadAll(t.response.Body)
-	}
+	t.ResponseBody = nil
 }
 
-// Post issues an HTTP POST request and keeps the response for later assertions.
-func (t *TestCase) Post(url string, contentType string, body []byte) {
-	if t.server == nil {
+// do issues an HTTP request with the given method and optional body and
+// keeps the response for later assertions. It backs Get, Post, Put, Delete,
+// and Patch.
+func (t *TestCase) do(method, url, contentType string, body []byte) {
+	if t.server == nil && t.handler == nil {
 		t.T.Fatalf("Uninitialized test server [%s]", CallerInfo())
 	}
-	url = t.server.URL + url
-	resp, err := http.Post(url, contentType, bytes.NewReader(body))
+	var reader *bytes.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	} else {
+		reader = bytes.NewReader([]byte{})
+	}
+	req, err := http.NewRequest(method, t.targetURL(url), reader)
+	if err != nil {
+		t.T.Fatalf("Failed to create new request: %s [%s]", err, CallerInfo())
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := t.dispatch(req)
+	t.request = req
 	t.response = resp
 	t.err = err
 	if err == nil {
@@ -166,25 +325,130 @@ func (t *TestCase) Post(url string, contentType string, body []byte) {
 	}
 }
 
-// Put issues an HTTP PUT request and keeps the response for later assertions
-func (t *TestCase) Put(url string, contentType string, body []byte) {
-	if t.server == nil {
+// targetURL resolves path against the live server's URL, or returns it
+// unchanged in mock mode, where no real URL is needed.
+func (t *TestCase) targetURL(path string) string {
+	if t.server != nil {
+		return t.server.URL + path
+	}
+	return path
+}
+
+// dispatch sends req through the live server via http.DefaultClient, or, in
+// mock mode, directly through the handler via an httptest.ResponseRecorder.
+func (t *TestCase) dispatch(req *http.Request) (*http.Response, error) {
+	if t.handler != nil {
+		rec := httptest.NewRecorder()
+		t.handler.ServeHTTP(rec, req)
+		return rec.Result(), nil
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// LastRequest returns the *http.Request sent by the most recent Get, Post,
+// Put, Delete, Patch, Head, or Request call.
+func (t *TestCase) LastRequest() *http.Request {
+	return t.request
+}
+
+// RequestBuilder builds up an HTTP request against a TestCase's test server
+// and sends it, chaining configuration calls before a final Send.
+type RequestBuilder struct {
+	t       *TestCase
+	method  string
+	path    string
+	header  http.Header
+	query   url.Values
+	body    io.Reader
+	user    string
+	pass    string
+	hasAuth bool
+}
+
+// Request returns a RequestBuilder for composing a request with custom
+// headers, query parameters, or basic auth before sending it.
+func (t *TestCase) Request() *RequestBuilder {
+	return &RequestBuilder{
+		t:      t,
+		method: http.MethodGet,
+		header: make(http.Header),
+		query:  make(url.Values),
+	}
+}
+
+// Method sets the HTTP method, e.g. "PATCH".
+func (r *RequestBuilder) Method(method string) *RequestBuilder {
+	r.method = method
+	return r
+}
+
+// Path sets the request path, relative to the test server's URL.
+func (r *RequestBuilder) Path(path string) *RequestBuilder {
+	r.path = path
+	return r
+}
+
+// Header sets a request header.
+func (r *RequestBuilder) Header(key, value string) *RequestBuilder {
+	r.header.Set(key, value)
+	return r
+}
+
+// Query sets a query string parameter.
+func (r *RequestBuilder) Query(key, value string) *RequestBuilder {
+	r.query.Set(key, value)
+	return r
+}
+
+// Body sets the request body and its content type.
+func (r *RequestBuilder) Body(contentType string, body []byte) *RequestBuilder {
+	r.header.Set("Content-Type", contentType)
+	r.body = bytes.NewReader(body)
+	return r
+}
+
+// JSONBody marshals i to JSON and uses it as the request body, setting the
+// Content-Type header to "application/json".
+func (r *RequestBuilder) JSONBody(i interface{}) *RequestBuilder {
+	return r.Body("application/json", r.t.Marshal(i))
+}
+
+// BasicAuth sets the request's Authorization header using HTTP basic auth.
+func (r *RequestBuilder) BasicAuth(username, password string) *RequestBuilder {
+	r.user = username
+	r.pass = password
+	r.hasAuth = true
+	return r
+}
+
+// Send issues the built request against the TestCase's test server and
+// keeps the response for later assertions.
+func (r *RequestBuilder) Send() *TestCase {
+	t := r.t
+	if t.server == nil && t.handler == nil {
 		t.T.Fatalf("Uninitialized test server [%s]", CallerInfo())
 	}
-	url = t.server.URL + url
-	req, err := http.NewRequest("PUT", url, bytes.NewReader(body))
+	reqURL := t.targetURL(r.path)
+	if len(r.query) > 0 {
+		reqURL += "?" + r.query.Encode()
+	}
+	req, err := http.NewRequest(r.method, reqURL, r.body)
 	if err != nil {
-		t.T.Fatalf("Failed to create new request: %s", err)
+		t.T.Fatalf("Failed to create new request: %s [%s]", err, CallerInfo())
 	}
-	req.Header.Set("Content-Type", contentType)
-
-	resp, err := http.DefaultClient.Do(req)
+	req.Header = r.header
+	if r.hasAuth {
+		req.SetBasicAuth(r.user, r.pass)
+	}
+	resp, err := t.dispatch(req)
+	t.request = req
 	t.response = resp
 	t.err = err
 	if err == nil {
-		defer t.response.Body.Close()
-		t.ResponseBody, t.err = ioutil.ReadAll(t.response.Body)
+		defer resp.Body.Close()
+		t.ResponseBody, t.err = ioutil.ReadAll(resp.Body)
 	}
+	return t
 }
 
 // Unmarshal unmarshals response  body into and store it into  i, the test fails
@@ -0,0 +1,269 @@
+package asserting
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AssertEqual tests that expected and actual are deeply equal, printing a
+// line-by-line diff of their "%#v" representations on failure.
+func (t TestCase) AssertEqual(expected, actual interface{}) {
+	if !reflect.DeepEqual(expected, actual) {
+		t.T.Fatalf("Values are not equal [%s]\n%s", CallerInfo(), diff(expected, actual))
+	}
+}
+
+// ExpectEqual is the non-halting counterpart of AssertEqual: it records the
+// failure via t.T.Errorf and lets the rest of the test keep running.
+func (t TestCase) ExpectEqual(expected, actual interface{}) {
+	if !reflect.DeepEqual(expected, actual) {
+		t.T.Errorf("Values are not equal [%s]\n%s", CallerInfo(), diff(expected, actual))
+	}
+}
+
+// AssertNotEqual tests that expected and actual are not deeply equal.
+func (t TestCase) AssertNotEqual(expected, actual interface{}) {
+	if reflect.DeepEqual(expected, actual) {
+		t.T.Fatalf("Expected values to differ, both are %#v [%s]", actual, CallerInfo())
+	}
+}
+
+// ExpectNotEqual is the non-halting counterpart of AssertNotEqual.
+func (t TestCase) ExpectNotEqual(expected, actual interface{}) {
+	if reflect.DeepEqual(expected, actual) {
+		t.T.Errorf("Expected values to differ, both are %#v [%s]", actual, CallerInfo())
+	}
+}
+
+// AssertContains tests that collection, a slice, array, map, or string,
+// contains element.
+func (t TestCase) AssertContains(collection, element interface{}) {
+	if !contains(collection, element) {
+		t.T.Fatalf("Expected %#v to contain %#v [%s]", collection, element, CallerInfo())
+	}
+}
+
+// ExpectContains is the non-halting counterpart of AssertContains.
+func (t TestCase) ExpectContains(collection, element interface{}) {
+	if !contains(collection, element) {
+		t.T.Errorf("Expected %#v to contain %#v [%s]", collection, element, CallerInfo())
+	}
+}
+
+// AssertLen tests that collection, a slice, array, map, string, or channel,
+// has the given length.
+func (t TestCase) AssertLen(collection interface{}, length int) {
+	n, ok := lengthOf(collection)
+	if !ok {
+		t.T.Fatalf("Expected %#v to be a type with a length [%s]", collection, CallerInfo())
+	}
+	if n != length {
+		t.T.Fatalf("Expected length %d, got %d [%s]", length, n, CallerInfo())
+	}
+}
+
+// ExpectLen is the non-halting counterpart of AssertLen.
+func (t TestCase) ExpectLen(collection interface{}, length int) {
+	n, ok := lengthOf(collection)
+	if !ok {
+		t.T.Errorf("Expected %#v to be a type with a length [%s]", collection, CallerInfo())
+		return
+	}
+	if n != length {
+		t.T.Errorf("Expected length %d, got %d [%s]", length, n, CallerInfo())
+	}
+}
+
+// AssertElementsMatch tests that expected and actual, both slices or
+// arrays, contain the same elements regardless of order.
+func (t TestCase) AssertElementsMatch(expected, actual interface{}) {
+	if !elementsMatch(expected, actual) {
+		t.T.Fatalf("Expected elements to match: %#v vs %#v [%s]", expected, actual, CallerInfo())
+	}
+}
+
+// ExpectElementsMatch is the non-halting counterpart of AssertElementsMatch.
+func (t TestCase) ExpectElementsMatch(expected, actual interface{}) {
+	if !elementsMatch(expected, actual) {
+		t.T.Errorf("Expected elements to match: %#v vs %#v [%s]", expected, actual, CallerInfo())
+	}
+}
+
+// AssertPanics tests that calling f panics.
+func (t TestCase) AssertPanics(f func()) {
+	defer func() {
+		if recover() == nil {
+			t.T.Fatalf("Expected function to panic, it did not [%s]", CallerInfo())
+		}
+	}()
+	f()
+}
+
+// AssertErrorIs tests that errors.Is(err, target) holds.
+func (t TestCase) AssertErrorIs(err, target error) {
+	if !errors.Is(err, target) {
+		t.T.Fatalf("Expected error %v to match target %v [%s]", err, target, CallerInfo())
+	}
+}
+
+// AssertErrorContains tests that err is non-nil and its message contains
+// substr.
+func (t TestCase) AssertErrorContains(err error, substr string) {
+	if err == nil {
+		t.T.Fatalf("Expected error containing %q, got nil [%s]", substr, CallerInfo())
+	}
+	if !strings.Contains(err.Error(), substr) {
+		t.T.Fatalf("Expected error %q to contain %q [%s]", err.Error(), substr, CallerInfo())
+	}
+}
+
+func contains(collection, element interface{}) bool {
+	cv := reflect.ValueOf(collection)
+	switch cv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < cv.Len(); i++ {
+			if reflect.DeepEqual(cv.Index(i).Interface(), element) {
+				return true
+			}
+		}
+		return false
+	case reflect.Map:
+		for _, k := range cv.MapKeys() {
+			if reflect.DeepEqual(cv.MapIndex(k).Interface(), element) {
+				return true
+			}
+		}
+		return false
+	case reflect.String:
+		s, ok := element.(string)
+		return ok && strings.Contains(cv.String(), s)
+	default:
+		return false
+	}
+}
+
+// lengthOf returns collection's length and whether it's a type that has one.
+func lengthOf(collection interface{}) (int, bool) {
+	cv := reflect.ValueOf(collection)
+	switch cv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return cv.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+func elementsMatch(expected, actual interface{}) bool {
+	ev := reflect.ValueOf(expected)
+	av := reflect.ValueOf(actual)
+	if (ev.Kind() != reflect.Slice && ev.Kind() != reflect.Array) ||
+		(av.Kind() != reflect.Slice && av.Kind() != reflect.Array) {
+		return false
+	}
+	if ev.Len() != av.Len() {
+		return false
+	}
+	used := make([]bool, av.Len())
+	for i := 0; i < ev.Len(); i++ {
+		found := false
+		for j := 0; j < av.Len(); j++ {
+			if used[j] {
+				continue
+			}
+			if reflect.DeepEqual(ev.Index(i).Interface(), av.Index(j).Interface()) {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// diff renders expected and actual with "%#v" and returns a line-by-line
+// unified diff of the two representations, prefixing removed lines with
+// "-" and added lines with "+". Loosely modeled after go-difflib, without
+// taking on the dependency.
+func diff(expected, actual interface{}) string {
+	return unifiedDiff(splitFields(fmt.Sprintf("%#v", expected)), splitFields(fmt.Sprintf("%#v", actual)))
+}
+
+// splitFields breaks a "%#v" rendering into one "line" per top-level field,
+// splitting on commas outside of nested brackets.
+func splitFields(s string) []string {
+	var fields []string
+	depth := 0
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '{', '[', '(':
+			depth++
+		case '}', ']', ')':
+			depth--
+		case ',':
+			if depth == 1 {
+				fields = append(fields, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	fields = append(fields, strings.TrimSpace(s[start:]))
+	return fields
+}
+
+func unifiedDiff(a, b []string) string {
+	lcs := lcsTable(a, b)
+	var rev []string
+	i, j := len(a), len(b)
+	for i > 0 && j > 0 {
+		switch {
+		case a[i-1] == b[j-1]:
+			rev = append(rev, "  "+a[i-1])
+			i--
+			j--
+		case lcs[i-1][j] >= lcs[i][j-1]:
+			rev = append(rev, "- "+a[i-1])
+			i--
+		default:
+			rev = append(rev, "+ "+b[j-1])
+			j--
+		}
+	}
+	for i > 0 {
+		rev = append(rev, "- "+a[i-1])
+		i--
+	}
+	for j > 0 {
+		rev = append(rev, "+ "+b[j-1])
+		j--
+	}
+	lines := make([]string, len(rev))
+	for k, l := range rev {
+		lines[len(rev)-1-k] = l
+	}
+	return strings.Join(lines, "\n")
+}
+
+func lcsTable(a, b []string) [][]int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				table[i][j] = table[i-1][j-1] + 1
+			} else if table[i-1][j] >= table[i][j-1] {
+				table[i][j] = table[i-1][j]
+			} else {
+				table[i][j] = table[i][j-1]
+			}
+		}
+	}
+	return table
+}
@@ -0,0 +1,37 @@
+package asserting
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAssertEqual(t *testing.T) {
+	tc := NewTestCase(t)
+	tc.AssertEqual([]int{1, 2, 3}, []int{1, 2, 3})
+	tc.AssertNotEqual([]int{1, 2, 3}, []int{1, 2, 4})
+}
+
+func TestAssertContainsAndLen(t *testing.T) {
+	tc := NewTestCase(t)
+	tc.AssertContains([]string{"a", "b", "c"}, "b")
+	tc.AssertContains("hello world", "world")
+	tc.AssertLen([]string{"a", "b", "c"}, 3)
+}
+
+func TestAssertElementsMatch(t *testing.T) {
+	tc := NewTestCase(t)
+	tc.AssertElementsMatch([]int{1, 2, 3}, []int{3, 1, 2})
+}
+
+func TestAssertPanics(t *testing.T) {
+	tc := NewTestCase(t)
+	tc.AssertPanics(func() { panic("boom") })
+}
+
+func TestAssertErrorIsAndContains(t *testing.T) {
+	tc := NewTestCase(t)
+	sentinel := errors.New("not found")
+	wrapped := errors.New("lookup failed: not found")
+	tc.AssertErrorIs(sentinel, sentinel)
+	tc.AssertErrorContains(wrapped, "not found")
+}
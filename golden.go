@@ -0,0 +1,93 @@
+package asserting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// goldenUpdateEnv is the environment variable that, when set to "1", makes
+// golden assertions rewrite their golden file instead of failing.
+const goldenUpdateEnv = "ASSERTING_UPDATE"
+
+// AssertResponseMatchesGolden compares t.ResponseBody against
+// testdata/<name>.golden, failing with a line-numbered unified diff on
+// mismatch. Set ASSERTING_UPDATE=1 to (re)write the golden file instead of
+// asserting against it.
+func (t *TestCase) AssertResponseMatchesGolden(name string) {
+	t.assertGolden(name, t.ResponseBody, false)
+}
+
+// AssertJSONEqualsGolden canonicalizes both t.ResponseBody and the golden
+// file (sorted keys, normalized whitespace) before comparing them, so
+// insignificant formatting differences don't fail the test.
+func (t *TestCase) AssertJSONEqualsGolden(name string) {
+	canonical, err := canonicalizeJSON(t.ResponseBody)
+	if err != nil {
+		t.T.Fatalf("Response is not valid JSON: %s [%s]", err, CallerInfo())
+	}
+	t.assertGolden(name, canonical, true)
+}
+
+func (t *TestCase) assertGolden(name string, actual []byte, canonicalize bool) {
+	path := goldenPath(name)
+	if os.Getenv(goldenUpdateEnv) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.T.Fatalf("Failed to create %s: %s [%s]", filepath.Dir(path), err, CallerInfo())
+		}
+		if err := ioutil.WriteFile(path, actual, 0644); err != nil {
+			t.T.Fatalf("Failed to write golden file %q: %s [%s]", path, err, CallerInfo())
+		}
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.T.Fatalf("Failed to read golden file %q: %s (run with %s=1 to create it) [%s]",
+			path, err, goldenUpdateEnv, CallerInfo())
+	}
+	if canonicalize {
+		expected, err = canonicalizeJSON(expected)
+		if err != nil {
+			t.T.Fatalf("Golden file %q is not valid JSON: %s [%s]", path, err, CallerInfo())
+		}
+	}
+	if !bytes.Equal(expected, actual) {
+		t.T.Fatalf("Response doesn't match golden file %q [%s]\n%s",
+			path, CallerInfo(), numberedDiff(string(expected), string(actual)))
+	}
+}
+
+func goldenPath(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+// canonicalizeJSON re-encodes data with sorted object keys and normalized
+// indentation, trimming the trailing newline json.Encoder adds.
+func canonicalizeJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// numberedDiff returns unifiedDiff's output with a 1-based line number
+// prefixed on each line.
+func numberedDiff(expected, actual string) string {
+	lines := strings.Split(unifiedDiff(strings.Split(expected, "\n"), strings.Split(actual, "\n")), "\n")
+	for i, l := range lines {
+		lines[i] = fmt.Sprintf("%4d %s", i+1, l)
+	}
+	return strings.Join(lines, "\n")
+}
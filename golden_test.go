@@ -0,0 +1,38 @@
+package asserting
+
+import (
+	"net/http"
+	"testing"
+)
+
+func greetHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/greet", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message":   "hi", "id": 1}`))
+	})
+	return mux
+}
+
+func TestAssertJSONEqualsGolden(t *testing.T) {
+	tc := NewWebTestCase(t, greetHandler())
+	tc.Get("/greet")
+	tc.AssertJSONEqualsGolden("greet")
+}
+
+func reorderedGreetHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/greet", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "message": "hi"}`))
+	})
+	return mux
+}
+
+// TestAssertJSONEqualsGoldenIgnoresKeyOrder guards against the golden file
+// itself being compared byte-for-byte: "greet_reordered.golden" has its keys
+// in the opposite order of the response body, so this only passes if both
+// sides get canonicalized before the comparison.
+func TestAssertJSONEqualsGoldenIgnoresKeyOrder(t *testing.T) {
+	tc := NewWebTestCase(t, reorderedGreetHandler())
+	tc.Get("/greet")
+	tc.AssertJSONEqualsGolden("greet_reordered")
+}
@@ -0,0 +1,249 @@
+package asserting
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// AssertJSONPath parses t.ResponseBody as JSON, evaluates expr against it,
+// and tests the result for deep equality with expected. expr mirrors common
+// JMESPath selectors: identifiers ("foo.bar"), index ("items[0]"), wildcard
+// projections ("items[*].name"), and simple filter predicates
+// ("items[?status=='active'].name").
+func (t *TestCase) AssertJSONPath(expr string, expected interface{}) {
+	value := t.evalJSONPath(expr)
+	if !reflect.DeepEqual(expected, value) {
+		t.T.Fatalf("Expected %q to equal %#v, got %#v [%s]", expr, expected, value, CallerInfo())
+	}
+}
+
+// AssertJSONPathExists parses t.ResponseBody as JSON and tests that expr
+// resolves to a value.
+func (t *TestCase) AssertJSONPathExists(expr string) {
+	t.evalJSONPath(expr)
+}
+
+// evalJSONPath unmarshals t.ResponseBody and evaluates expr against it,
+// failing the test if the body isn't valid JSON or expr doesn't resolve.
+func (t *TestCase) evalJSONPath(expr string) interface{} {
+	var doc interface{}
+	if err := json.Unmarshal(t.ResponseBody, &doc); err != nil {
+		t.T.Fatalf("Response is not valid JSON: %s [%s]", err, CallerInfo())
+	}
+	value, ok := evalJSONPath(doc, expr)
+	if !ok {
+		t.T.Fatalf("Path %q not found in response [%s]", expr, CallerInfo())
+	}
+	return value
+}
+
+// pathSegment is an optional identifier followed by zero or more bracket
+// operations, e.g. "items[0]" is one segment with key "items" and a single
+// index op, while "items[*].name" is two segments.
+type pathSegment struct {
+	key   string
+	index []indexOp
+}
+
+type indexOp struct {
+	kind string // "idx", "wildcard", or "filter"
+
+	idx int // for "idx"
+
+	filterKey string // for "filter"
+	filterOp  string
+	filterVal string
+}
+
+// evalJSONPath evaluates a JMESPath-like expression against doc, a value
+// produced by json.Unmarshal, returning the result and whether expr
+// resolved to anything.
+func evalJSONPath(doc interface{}, expr string) (interface{}, bool) {
+	segments, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, false
+	}
+
+	current := doc
+	projecting := false
+	found := true
+	for _, seg := range segments {
+		if seg.key != "" {
+			current, projecting, found = stepKey(current, seg.key, projecting)
+			if !found {
+				return nil, false
+			}
+		}
+		for _, op := range seg.index {
+			current, projecting, found = stepIndex(current, op, projecting)
+			if !found {
+				return nil, false
+			}
+		}
+	}
+	return current, true
+}
+
+// stepKey resolves key against current, returning the next value, whether
+// the result is a projected slice, and whether key was found at all. found
+// is tracked separately from the value so a key present with a JSON null
+// value isn't mistaken for a missing key.
+func stepKey(current interface{}, key string, projecting bool) (interface{}, bool, bool) {
+	if !projecting {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false, false
+		}
+		v, ok := m[key]
+		if !ok {
+			return nil, false, false
+		}
+		return v, false, true
+	}
+	list, ok := current.([]interface{})
+	if !ok {
+		return nil, true, true
+	}
+	var next []interface{}
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			if v, ok := m[key]; ok {
+				next = append(next, v)
+			}
+		}
+	}
+	return next, true, true
+}
+
+// stepIndex applies op to current, returning the next value, whether the
+// result is a projected slice, and whether op resolved to anything. found is
+// tracked separately from the value so a resolved index whose value is null
+// isn't mistaken for an out-of-range index.
+func stepIndex(current interface{}, op indexOp, projecting bool) (interface{}, bool, bool) {
+	switch op.kind {
+	case "wildcard":
+		if projecting {
+			var flattened []interface{}
+			for _, item := range current.([]interface{}) {
+				if arr, ok := item.([]interface{}); ok {
+					flattened = append(flattened, arr...)
+				}
+			}
+			return flattened, true, true
+		}
+		arr, ok := current.([]interface{})
+		if !ok {
+			return nil, true, true
+		}
+		return arr, true, true
+	case "filter":
+		arr, ok := current.([]interface{})
+		if !ok {
+			return nil, true, true
+		}
+		var next []interface{}
+		for _, item := range arr {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			v, ok := m[op.filterKey]
+			if !ok {
+				continue
+			}
+			if matchesFilter(v, op.filterOp, op.filterVal) {
+				next = append(next, item)
+			}
+		}
+		return next, true, true
+	default: // "idx"
+		if !projecting {
+			arr, ok := current.([]interface{})
+			if !ok || op.idx < 0 || op.idx >= len(arr) {
+				return nil, false, false
+			}
+			return arr[op.idx], false, true
+		}
+		list, ok := current.([]interface{})
+		if !ok {
+			return nil, true, true
+		}
+		var next []interface{}
+		for _, item := range list {
+			arr, ok := item.([]interface{})
+			if !ok || op.idx < 0 || op.idx >= len(arr) {
+				continue
+			}
+			next = append(next, arr[op.idx])
+		}
+		return next, true, true
+	}
+}
+
+func matchesFilter(v interface{}, op, want string) bool {
+	got := fmt.Sprintf("%v", v)
+	switch op {
+	case "!=":
+		return got != want
+	default: // "=="
+		return got == want
+	}
+}
+
+// parseJSONPath splits expr into a sequence of pathSegments.
+func parseJSONPath(expr string) ([]pathSegment, error) {
+	var segments []pathSegment
+	i, n := 0, len(expr)
+	for i < n {
+		if expr[i] == '.' {
+			i++
+			continue
+		}
+		var seg pathSegment
+		start := i
+		for i < n && expr[i] != '.' && expr[i] != '[' {
+			i++
+		}
+		seg.key = expr[start:i]
+		for i < n && expr[i] == '[' {
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("asserting: unterminated '[' in %q", expr)
+			}
+			op, err := parseIndexOp(expr[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			seg.index = append(seg.index, op)
+			i += end + 1
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+func parseIndexOp(inner string) (indexOp, error) {
+	inner = strings.TrimSpace(inner)
+	if inner == "*" {
+		return indexOp{kind: "wildcard"}, nil
+	}
+	if strings.HasPrefix(inner, "?") {
+		pred := inner[1:]
+		for _, op := range []string{"==", "!="} {
+			if idx := strings.Index(pred, op); idx >= 0 {
+				key := strings.TrimSpace(pred[:idx])
+				val := strings.Trim(strings.TrimSpace(pred[idx+len(op):]), `'"`)
+				return indexOp{kind: "filter", filterKey: key, filterOp: op, filterVal: val}, nil
+			}
+		}
+		return indexOp{}, fmt.Errorf("asserting: unsupported filter predicate %q", inner)
+	}
+	idx, err := strconv.Atoi(inner)
+	if err != nil {
+		return indexOp{}, fmt.Errorf("asserting: invalid index %q", inner)
+	}
+	return indexOp{kind: "idx", idx: idx}, nil
+}
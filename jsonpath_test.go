@@ -0,0 +1,46 @@
+package asserting
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func usersListHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"users": []map[string]interface{}{
+				{"name": "Ada", "status": "active"},
+				{"name": "Linus", "status": "inactive"},
+			},
+		})
+	})
+	return mux
+}
+
+func TestAssertJSONPath(t *testing.T) {
+	tc := NewWebTestCase(t, usersListHandler())
+	tc.Get("/users")
+
+	tc.AssertJSONPath("users[0].name", "Ada")
+	tc.AssertJSONPath("users[*].name", []interface{}{"Ada", "Linus"})
+	tc.AssertJSONPath("users[?status=='active'].name", []interface{}{"Ada"})
+	tc.AssertJSONPathExists("users[1].status")
+}
+
+func nullFieldHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/thing", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"foo": nil})
+	})
+	return mux
+}
+
+func TestAssertJSONPathNullValue(t *testing.T) {
+	tc := NewWebTestCase(t, nullFieldHandler())
+	tc.Get("/thing")
+
+	tc.AssertJSONPathExists("foo")
+	tc.AssertJSONPath("foo", nil)
+}
@@ -0,0 +1,113 @@
+package asserting
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestAfterEachRunsPerTest(t *testing.T) {
+	ran := []string{}
+	calls := 0
+	s := &afterEachSuite{TestCase: TestCase{T: t}, ran: &ran, calls: &calls}
+	Run(s)
+	if *s.calls != 2 {
+		t.Fatalf("Expected AfterEach to run once per test (2), got %d", *s.calls)
+	}
+}
+
+type afterEachSuite struct {
+	TestCase
+	ran   *[]string
+	calls *int
+}
+
+func (s *afterEachSuite) TestA()     { *s.ran = append(*s.ran, "TestA") }
+func (s *afterEachSuite) TestB()     { *s.ran = append(*s.ran, "TestB") }
+func (s *afterEachSuite) AfterEach() { *s.calls++ }
+
+func TestAfterAllRunsOnce(t *testing.T) {
+	calls := 0
+	s := &afterAllSuite{TestCase: TestCase{T: t}, calls: &calls}
+	Run(s)
+	if *s.calls != 1 {
+		t.Fatalf("Expected AfterAll to run exactly once, got %d", *s.calls)
+	}
+}
+
+type afterAllSuite struct {
+	TestCase
+	calls *int
+}
+
+func (s *afterAllSuite) TestA()    {}
+func (s *afterAllSuite) TestB()    {}
+func (s *afterAllSuite) AfterAll() { *s.calls++ }
+
+func TestFocusRunsOnlyFTests(t *testing.T) {
+	ran := []string{}
+	s := &focusSuite{TestCase: TestCase{T: t}, ran: &ran}
+	Run(s)
+	if len(ran) != 1 || ran[0] != "FTestB" {
+		t.Fatalf("Expected only FTestB to run, got %v", ran)
+	}
+}
+
+type focusSuite struct {
+	TestCase
+	ran *[]string
+}
+
+func (s *focusSuite) TestA()  { *s.ran = append(*s.ran, "TestA") }
+func (s *focusSuite) FTestB() { *s.ran = append(*s.ran, "FTestB") }
+func (s *focusSuite) TestC()  { *s.ran = append(*s.ran, "TestC") }
+
+func TestSkipIsNotRun(t *testing.T) {
+	ran := []string{}
+	s := &skipSuite{TestCase: TestCase{T: t}, ran: &ran}
+	Run(s)
+	if len(ran) != 1 || ran[0] != "TestA" {
+		t.Fatalf("Expected only TestA to run, got %v", ran)
+	}
+}
+
+type skipSuite struct {
+	TestCase
+	ran *[]string
+}
+
+func (s *skipSuite) TestA()  { *s.ran = append(*s.ran, "TestA") }
+func (s *skipSuite) XTestB() { *s.ran = append(*s.ran, "XTestB") }
+
+// panicSuite's single test panics; AfterEach must still run before the
+// panic re-propagates and crashes the process, as it would for any
+// uncaught panic in a Go test. TestAfterEachRunsOnPanic drives this suite
+// in a subprocess so the crash doesn't take down the rest of the package's
+// test run, and checks the AfterEach side effect made it to stdout first.
+type panicSuite struct {
+	TestCase
+}
+
+func (s *panicSuite) TestBoom()  { panic("boom") }
+func (s *panicSuite) AfterEach() { fmt.Println("AFTER_EACH_RAN") }
+
+func TestAfterEachRunsOnPanicHelper(t *testing.T) {
+	if os.Getenv("ASSERTING_PANIC_HELPER") != "1" {
+		t.Skip("only runs as a subprocess helper for TestAfterEachRunsOnPanic")
+	}
+	Run(&panicSuite{TestCase: TestCase{T: t}})
+}
+
+func TestAfterEachRunsOnPanic(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestAfterEachRunsOnPanicHelper$", "-test.v")
+	cmd.Env = append(os.Environ(), "ASSERTING_PANIC_HELPER=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("Expected the helper process to crash on the panic, it exited cleanly:\n%s", out)
+	}
+	if !strings.Contains(string(out), "AFTER_EACH_RAN") {
+		t.Fatalf("Expected AfterEach to run before the panic propagated, got:\n%s", out)
+	}
+}
@@ -0,0 +1,31 @@
+package asserting
+
+import (
+	"net/http"
+	"testing"
+)
+
+func echoHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Echo", r.Header.Get("X-Echo"))
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+func TestNewMockTestCase(t *testing.T) {
+	tc := NewMockTestCase(t, echoHandler())
+	tc.Request().Method("GET").Path("/echo").Header("X-Echo", "hi").Send()
+	tc.AssertOK()
+	if got := tc.response.Header.Get("X-Echo"); got != "hi" {
+		t.Fatalf("Expected echoed header %q, got %q", "hi", got)
+	}
+}
+
+func TestClose(t *testing.T) {
+	tc := NewWebTestCase(t, echoHandler())
+	tc.Get("/echo")
+	tc.AssertOK()
+	tc.Close()
+}
@@ -0,0 +1,63 @@
+package asserting
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func echoRequestHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Query", r.URL.Query().Get("page"))
+		user, pass, ok := r.BasicAuth()
+		if ok {
+			w.Header().Set("X-User", user)
+			w.Header().Set("X-Pass", pass)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	})
+	return mux
+}
+
+func TestRequestBuilderChain(t *testing.T) {
+	tc := NewWebTestCase(t, echoRequestHandler())
+	tc.Request().
+		Method("PATCH").
+		Path("/echo").
+		Header("Accept", "application/json").
+		Query("page", "2").
+		BasicAuth("ada", "secret").
+		JSONBody(map[string]string{"hello": "world"}).
+		Send()
+
+	tc.AssertOK()
+	tc.AssertEqualStr("PATCH", tc.response.Header.Get("X-Method"))
+	tc.AssertEqualStr("2", tc.response.Header.Get("X-Query"))
+	tc.AssertEqualStr("ada", tc.response.Header.Get("X-User"))
+	tc.AssertEqualStr("secret", tc.response.Header.Get("X-Pass"))
+	tc.AssertJSONPath("hello", "world")
+
+	if tc.LastRequest() == nil || tc.LastRequest().Method != "PATCH" {
+		t.Fatalf("Expected LastRequest to be the PATCH request, got %#v", tc.LastRequest())
+	}
+}
+
+func TestVerbHelpers(t *testing.T) {
+	tc := NewWebTestCase(t, echoRequestHandler())
+
+	tc.Delete("/echo")
+	tc.AssertOK()
+	tc.AssertEqualStr("DELETE", tc.LastRequest().Method)
+
+	tc.Patch("/echo", "application/json", []byte(`{"a":1}`))
+	tc.AssertOK()
+	tc.AssertEqualStr("PATCH", tc.LastRequest().Method)
+	tc.AssertEqualStr(`{"a":1}`, string(tc.ResponseBody))
+
+	tc.Head("/echo")
+	tc.AssertOK()
+	tc.AssertEqualStr("HEAD", tc.LastRequest().Method)
+}
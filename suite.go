@@ -0,0 +1,283 @@
+package asserting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Matcher asserts that the value found at a JSONPath-like expression in a
+// case's response body matches an expected value or a regular expression.
+// Only one of Equals or Regex should be set.
+type Matcher struct {
+	Path   string      `json:"path"`
+	Equals interface{} `json:"equals,omitempty"`
+	Regex  string      `json:"regex,omitempty"`
+}
+
+// Case describes a single declarative HTTP test case, as loaded from a
+// suite file by LoadSuite.
+type Case struct {
+	Name           string            `json:"name"`
+	Method         string            `json:"method"`
+	Path           string            `json:"path"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Body           json.RawMessage   `json:"body,omitempty"`
+	ExpectedStatus int               `json:"expectedStatus,omitempty"`
+	ExpectedBody   json.RawMessage   `json:"expectedBody,omitempty"`
+	Matchers       []Matcher         `json:"matchers,omitempty"`
+}
+
+// LoadSuite reads a declarative test suite from a YAML (.yaml/.yml) or JSON
+// file and returns its cases. It panics if the file cannot be read or
+// parsed, since a broken suite file is a test setup error rather than a
+// test failure.
+func LoadSuite(path string) []Case {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		panic(fmt.Sprintf("asserting: failed to read suite %q: %s", path, err))
+	}
+
+	var cases []Case
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		raw, err := parseYAML(data)
+		if err != nil {
+			panic(fmt.Sprintf("asserting: failed to parse suite %q: %s", path, err))
+		}
+		normalized, err := json.Marshal(raw)
+		if err != nil {
+			panic(fmt.Sprintf("asserting: failed to normalize suite %q: %s", path, err))
+		}
+		if err := json.Unmarshal(normalized, &cases); err != nil {
+			panic(fmt.Sprintf("asserting: failed to decode suite %q: %s", path, err))
+		}
+	default:
+		if err := json.Unmarshal(data, &cases); err != nil {
+			panic(fmt.Sprintf("asserting: failed to decode suite %q: %s", path, err))
+		}
+	}
+	return cases
+}
+
+// RunSuite executes each case as a Go subtest against t's test server,
+// asserting the expected status, body, and any matchers.
+func (t *TestCase) RunSuite(cases []Case) {
+	for _, c := range cases {
+		c := c
+		t.T.Run(c.Name, func(st *testing.T) {
+			sub := &TestCase{T: st, server: t.server, handler: t.handler}
+			method := c.Method
+			if method == "" {
+				method = http.MethodGet
+			}
+			builder := sub.Request().Method(method).Path(c.Path)
+			for k, v := range c.Headers {
+				builder.Header(k, v)
+			}
+			if len(c.Body) > 0 {
+				builder.Body("application/json", c.Body)
+			}
+			builder.Send()
+
+			if c.ExpectedStatus != 0 {
+				sub.AssertStatus(c.ExpectedStatus)
+			}
+			if len(c.ExpectedBody) > 0 {
+				var expected, actual interface{}
+				if err := json.Unmarshal(c.ExpectedBody, &expected); err != nil {
+					st.Fatalf("Invalid expectedBody for case %q: %s", c.Name, err)
+				}
+				if err := json.Unmarshal(sub.ResponseBody, &actual); err != nil {
+					st.Fatalf("Response is not valid JSON for case %q: %s [%s]", c.Name, err, CallerInfo())
+				}
+				if !reflect.DeepEqual(expected, actual) {
+					st.Fatalf("Expected body %#v, got %#v [%s]", expected, actual, CallerInfo())
+				}
+			}
+			for _, m := range c.Matchers {
+				assertMatcher(st, sub.ResponseBody, m)
+			}
+		})
+	}
+}
+
+// assertMatcher evaluates a single Matcher's path against body and fails
+// st if the matcher's expectation isn't met.
+func assertMatcher(st *testing.T, body []byte, m Matcher) {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		st.Fatalf("Response is not valid JSON: %s", err)
+		return
+	}
+	value, ok := evalJSONPath(doc, m.Path)
+	if !ok {
+		st.Fatalf("Path %q not found in response", m.Path)
+		return
+	}
+	if m.Regex != "" {
+		re, err := regexp.Compile(m.Regex)
+		if err != nil {
+			st.Fatalf("Invalid regex %q: %s", m.Regex, err)
+			return
+		}
+		if !re.MatchString(fmt.Sprintf("%v", value)) {
+			st.Fatalf("Expected %q to match %q, got %v", m.Path, m.Regex, value)
+		}
+		return
+	}
+	if !reflect.DeepEqual(m.Equals, value) {
+		st.Fatalf("Expected %q to equal %v, got %v", m.Path, m.Equals, value)
+	}
+}
+
+// parseYAML parses a small subset of YAML sufficient for declarative suite
+// files: nested mappings and sequences of scalars, using 2-space-style
+// indentation. Flow style, anchors, and multi-line scalars aren't
+// supported; no third-party dependency is worth pulling in for that.
+func parseYAML(data []byte) (interface{}, error) {
+	var lines []string
+	for _, l := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(l, " \r\t")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	p := &yamlParser{lines: lines}
+	return p.parseBlock(indentOf(lines[0]))
+}
+
+type yamlParser struct {
+	lines []string
+	pos   int
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func (p *yamlParser) peek() (string, bool) {
+	if p.pos >= len(p.lines) {
+		return "", false
+	}
+	return p.lines[p.pos], true
+}
+
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	line, ok := p.peek()
+	if !ok {
+		return nil, nil
+	}
+	if indentOf(line) != indent {
+		return nil, fmt.Errorf("unexpected indentation at %q", line)
+	}
+	if strings.HasPrefix(strings.TrimSpace(line), "-") {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+func (p *yamlParser) parseSequence(indent int) ([]interface{}, error) {
+	var seq []interface{}
+	for {
+		line, ok := p.peek()
+		if !ok || indentOf(line) != indent || !strings.HasPrefix(strings.TrimSpace(line), "-") {
+			break
+		}
+		rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "-"))
+		if rest == "" {
+			p.pos++
+			val, err := p.parseBlock(indent + 2)
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, val)
+			continue
+		}
+		if !strings.Contains(rest, ": ") && !strings.HasSuffix(rest, ":") {
+			p.pos++
+			seq = append(seq, parseScalar(rest))
+			continue
+		}
+		// Rewrite "- key: value" as a plain line at indent+2 so the
+		// mapping parser can pick up the rest of the item's keys.
+		p.lines[p.pos] = strings.Repeat(" ", indent+2) + rest
+		val, err := p.parseBlock(indent + 2)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, val)
+	}
+	return seq, nil
+}
+
+func (p *yamlParser) parseMapping(indent int) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	for {
+		line, ok := p.peek()
+		if !ok || indentOf(line) != indent {
+			break
+		}
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "-") {
+			break
+		}
+		sep := strings.Index(trimmed, ":")
+		if sep < 0 {
+			return nil, fmt.Errorf("expected \"key: value\", got %q", line)
+		}
+		key := strings.TrimSpace(trimmed[:sep])
+		value := strings.TrimSpace(trimmed[sep+1:])
+		p.pos++
+		if value != "" {
+			m[key] = parseScalar(value)
+			continue
+		}
+		next, ok := p.peek()
+		if !ok || indentOf(next) <= indent {
+			m[key] = nil
+			continue
+		}
+		val, err := p.parseBlock(indentOf(next))
+		if err != nil {
+			return nil, err
+		}
+		m[key] = val
+	}
+	return m, nil
+}
+
+func parseScalar(s string) interface{} {
+	if len(s) >= 2 {
+		if (s[0] == '"' && s[len(s)-1] == '"') || (s[0] == '\'' && s[len(s)-1] == '\'') {
+			return s[1 : len(s)-1]
+		}
+	}
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null", "~":
+		return nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
@@ -0,0 +1,49 @@
+package asserting
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func usersHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"name": "Ada Lovelace"})
+	})
+	mux.HandleFunc("/users/404", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	return mux
+}
+
+func TestLoadSuiteJSON(t *testing.T) {
+	cases := LoadSuite("testdata/users_suite.json")
+	if len(cases) != 2 {
+		t.Fatalf("Expected 2 cases, got %d", len(cases))
+	}
+	if cases[0].Name != "get existing user" || cases[0].ExpectedStatus != 200 {
+		t.Fatalf("Unexpected first case: %#v", cases[0])
+	}
+}
+
+func TestLoadSuiteYAML(t *testing.T) {
+	cases := LoadSuite("testdata/users_suite.yaml")
+	if len(cases) != 2 {
+		t.Fatalf("Expected 2 cases, got %d", len(cases))
+	}
+	if cases[1].Name != "get missing user" || cases[1].ExpectedStatus != 404 {
+		t.Fatalf("Unexpected second case: %#v", cases[1])
+	}
+}
+
+func TestRunSuite(t *testing.T) {
+	tc := NewWebTestCase(t, usersHandler())
+	tc.RunSuite(LoadSuite("testdata/users_suite.json"))
+	tc.RunSuite(LoadSuite("testdata/users_suite.yaml"))
+}
+
+func TestRunSuiteWithMockTestCase(t *testing.T) {
+	tc := NewMockTestCase(t, usersHandler())
+	tc.RunSuite(LoadSuite("testdata/users_suite.json"))
+}